@@ -0,0 +1,237 @@
+package st7735
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+
+	"periph.io/x/periph/conn/display"
+)
+
+var _ display.Drawer = &Dev{}
+
+// rgb565Model is the panel's native color.Model: every color is rounded to
+// what RGB565 can represent before being sent over SPI.
+var rgb565Model = color.ModelFunc(func(c color.Color) color.Color {
+	r, g, b, a := c.RGBA()
+	v := rgb565(r, g, b)
+	r = uint32(v>>11&0x1F) * 0xFFFF / 0x1F
+	g = uint32(v>>5&0x3F) * 0xFFFF / 0x3F
+	b = uint32(v&0x1F) * 0xFFFF / 0x1F
+	return color.RGBA64{R: uint16(r), G: uint16(g), B: uint16(b), A: uint16(a)}
+})
+
+// ColorModel implements display.Drawer. The panel stores RGB565, so colors
+// are rounded down to that before being drawn.
+func (d *Dev) ColorModel() color.Model {
+	return rgb565Model
+}
+
+// Bounds implements display.Drawer. It returns the logical panel dimensions
+// for the currently active rotation.
+func (d *Dev) Bounds() image.Rectangle {
+	w, h := d.dimensions()
+	return image.Rect(0, 0, w, h)
+}
+
+// SetImage draws img over the whole panel. img must have the same
+// dimensions as Bounds(). Only the scanlines that actually changed since
+// the previous frame are transmitted to the panel, using a shadow
+// framebuffer to diff against; the very first call always sends the whole
+// frame, since the panel's RAM is in an undefined state until then.
+func (d *Dev) SetImage(img image.Image) error {
+	w, h := d.dimensions()
+	if got := img.Bounds().Size(); got.X != w || got.Y != h {
+		return fmt.Errorf("st7735: SetImage: image is %dx%d, panel is %dx%d", got.X, got.Y, w, h)
+	}
+	d.ensureFramebuffer(w, h)
+
+	buf := toRGB565(img)
+	stride := w * 2
+
+	startRow, endRow := 0, h-1
+	if d.fbPrimed {
+		startRow, endRow = -1, -1
+		for y := 0; y < h; y++ {
+			row := buf[y*stride : (y+1)*stride]
+			prev := d.fb[y*stride : (y+1)*stride]
+			if !bytes.Equal(row, prev) {
+				if startRow == -1 {
+					startRow = y
+				}
+				endRow = y
+			}
+		}
+		if startRow == -1 {
+			return nil
+		}
+	}
+	copy(d.fb, buf)
+	d.fbPrimed = true
+
+	x0, x1, y0, _ := d.windowOffsets()
+	if err := d.setWindowAddress(x0, x1, y0+uint16(startRow), y0+uint16(endRow)); err != nil {
+		return err
+	}
+	return d.sendData(buf[startRow*stride : (endRow+1)*stride])
+}
+
+// SetImageRect draws src with its origin at dst, clipped to the panel
+// bounds, transmitting only the pixels it covers.
+func (d *Dev) SetImageRect(dst image.Point, src image.Image) error {
+	w, h := d.dimensions()
+	r := image.Rectangle{Min: dst, Max: dst.Add(src.Bounds().Size())}.Intersect(image.Rect(0, 0, w, h))
+	if r.Empty() {
+		return nil
+	}
+	return d.draw(r, src, src.Bounds().Min.Add(r.Min.Sub(dst)))
+}
+
+// Draw implements display.Drawer. It draws the portion of src starting at
+// sp into r, clipped to the panel bounds, transmitting only the pixels
+// inside r.
+func (d *Dev) Draw(r image.Rectangle, src image.Image, sp image.Point) error {
+	w, h := d.dimensions()
+	clipped := r.Intersect(image.Rect(0, 0, w, h))
+	if clipped.Empty() {
+		return nil
+	}
+	return d.draw(clipped, src, sp.Add(clipped.Min.Sub(r.Min)))
+}
+
+// draw converts the src pixels covering r into the shadow framebuffer and
+// transmits just that window to the panel.
+func (d *Dev) draw(r image.Rectangle, src image.Image, sp image.Point) error {
+	w, h := d.dimensions()
+	d.ensureFramebuffer(w, h)
+	stride := w * 2
+
+	for y := 0; y < r.Dy(); y++ {
+		row := d.fb[(r.Min.Y+y)*stride+r.Min.X*2 : (r.Min.Y+y)*stride+r.Max.X*2]
+		for x := 0; x < r.Dx(); x++ {
+			cr, cg, cb, _ := src.At(sp.X+x, sp.Y+y).RGBA()
+			binary.BigEndian.PutUint16(row[x*2:x*2+2], rgb565(cr, cg, cb))
+		}
+	}
+
+	x0, _, y0, _ := d.windowOffsets()
+	if err := d.setWindowAddress(x0+uint16(r.Min.X), x0+uint16(r.Max.X-1), y0+uint16(r.Min.Y), y0+uint16(r.Max.Y-1)); err != nil {
+		return err
+	}
+
+	data := make([]byte, r.Dy()*r.Dx()*2)
+	rowBytes := r.Dx() * 2
+	for y := 0; y < r.Dy(); y++ {
+		row := d.fb[(r.Min.Y+y)*stride+r.Min.X*2 : (r.Min.Y+y)*stride+r.Max.X*2]
+		copy(data[y*rowBytes:(y+1)*rowBytes], row)
+	}
+	return d.sendData(data)
+}
+
+// ensureFramebuffer (re)allocates the shadow framebuffer used to track
+// what has already been sent to the panel, for the given dimensions.
+func (d *Dev) ensureFramebuffer(w, h int) {
+	if len(d.fb) != w*h*2 {
+		d.fb = make([]byte, w*h*2)
+		// The panel's RAM still holds whatever was last drawn at the old
+		// dimensions, so the new, zeroed fb can't be trusted as a diff
+		// baseline.
+		d.fbPrimed = false
+	}
+}
+
+// rgb565 packs 16-bit RGBA channels, as returned by color.Color.RGBA(),
+// into a big-endian RGB565 value.
+func rgb565(r, g, b uint32) uint16 {
+	return uint16(r>>11)<<11 | uint16(g>>10)<<5 | uint16(b>>11)
+}
+
+// toRGB565 converts img to big-endian RGB565, row by row starting at
+// Bounds().Min, matching the row-major order memoryWrite expects. Common
+// concrete image types are converted directly from their pixel buffer,
+// bypassing the color.Color interface, since that's measurably faster on
+// slow CPUs such as a Pi Zero.
+func toRGB565(img image.Image) []byte {
+	b := img.Bounds()
+	buf := make([]byte, b.Dx()*b.Dy()*2)
+
+	switch src := img.(type) {
+	case *image.RGBA:
+		rgbaToRGB565(buf, src, b)
+	case *image.NRGBA:
+		nrgbaToRGB565(buf, src, b)
+	case *image.Gray:
+		grayToRGB565(buf, src, b)
+	default:
+		genericToRGB565(buf, img, b)
+	}
+
+	return buf
+}
+
+// genericToRGB565 is the slow path for any image.Image, going through the
+// color.Color interface.
+func genericToRGB565(dst []byte, img image.Image, b image.Rectangle) {
+	i := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, _ := img.At(x, y).RGBA()
+			binary.BigEndian.PutUint16(dst[i:i+2], rgb565(r, g, bl))
+			i += 2
+		}
+	}
+}
+
+// rgbaToRGB565 reads directly from an *image.RGBA's pixel buffer, skipping
+// the color.Color interface and the unpremultiply/premultiply round trip
+// At() would otherwise do.
+func rgbaToRGB565(dst []byte, src *image.RGBA, b image.Rectangle) {
+	i := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		o := (y-src.Rect.Min.Y)*src.Stride + (b.Min.X-src.Rect.Min.X)*4
+		for x := b.Min.X; x < b.Max.X; x++ {
+			binary.BigEndian.PutUint16(dst[i:i+2], pack565(src.Pix[o], src.Pix[o+1], src.Pix[o+2]))
+			i += 2
+			o += 4
+		}
+	}
+}
+
+// nrgbaToRGB565 reads directly from an *image.NRGBA's pixel buffer. Unlike
+// the generic path, which premultiplies through At().RGBA(), this reads
+// straight (non-premultiplied) alpha bytes, so it diverges from the generic
+// path for non-opaque pixels. The panel has no alpha channel, so this is
+// harmless in practice, but note it if NRGBA images are ever sourced
+// elsewhere.
+func nrgbaToRGB565(dst []byte, src *image.NRGBA, b image.Rectangle) {
+	i := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		o := (y-src.Rect.Min.Y)*src.Stride + (b.Min.X-src.Rect.Min.X)*4
+		for x := b.Min.X; x < b.Max.X; x++ {
+			binary.BigEndian.PutUint16(dst[i:i+2], pack565(src.Pix[o], src.Pix[o+1], src.Pix[o+2]))
+			i += 2
+			o += 4
+		}
+	}
+}
+
+// grayToRGB565 reads directly from an *image.Gray's pixel buffer.
+func grayToRGB565(dst []byte, src *image.Gray, b image.Rectangle) {
+	i := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		o := (y-src.Rect.Min.Y)*src.Stride + (b.Min.X - src.Rect.Min.X)
+		for x := b.Min.X; x < b.Max.X; x++ {
+			v := src.Pix[o]
+			binary.BigEndian.PutUint16(dst[i:i+2], pack565(v, v, v))
+			i += 2
+			o++
+		}
+	}
+}
+
+// pack565 packs 8-bit-per-channel color into big-endian RGB565.
+func pack565(r, g, b uint8) uint16 {
+	return uint16(r&0xF8)<<8 | uint16(g&0xFC)<<3 | uint16(b)>>3
+}
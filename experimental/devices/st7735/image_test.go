@@ -0,0 +1,129 @@
+package st7735
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestPack565(t *testing.T) {
+	tests := []struct {
+		name       string
+		r, g, b    uint8
+		wantHiByte byte
+		wantLoByte byte
+	}{
+		{"black", 0x00, 0x00, 0x00, 0x00, 0x00},
+		{"white", 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+		{"pure red", 0xFF, 0x00, 0x00, 0xF8, 0x00},
+		{"pure green", 0x00, 0xFF, 0x00, 0x07, 0xE0},
+		{"pure blue", 0x00, 0x00, 0xFF, 0x00, 0x1F},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := pack565(tt.r, tt.g, tt.b)
+			if hi, lo := byte(v>>8), byte(v); hi != tt.wantHiByte || lo != tt.wantLoByte {
+				t.Errorf("pack565(%#x, %#x, %#x) = %#04x, want %02x%02x", tt.r, tt.g, tt.b, v, tt.wantHiByte, tt.wantLoByte)
+			}
+		})
+	}
+}
+
+func TestRgb565(t *testing.T) {
+	tests := []struct {
+		name       string
+		r, g, b    uint32
+		wantHiByte byte
+		wantLoByte byte
+	}{
+		{"black", 0x0000, 0x0000, 0x0000, 0x00, 0x00},
+		{"white", 0xFFFF, 0xFFFF, 0xFFFF, 0xFF, 0xFF},
+		{"pure red", 0xFFFF, 0x0000, 0x0000, 0xF8, 0x00},
+		{"pure green", 0x0000, 0xFFFF, 0x0000, 0x07, 0xE0},
+		{"pure blue", 0x0000, 0x0000, 0xFFFF, 0x00, 0x1F},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := rgb565(tt.r, tt.g, tt.b)
+			if hi, lo := byte(v>>8), byte(v); hi != tt.wantHiByte || lo != tt.wantLoByte {
+				t.Errorf("rgb565(%#x, %#x, %#x) = %#04x, want %02x%02x", tt.r, tt.g, tt.b, v, tt.wantHiByte, tt.wantLoByte)
+			}
+		})
+	}
+}
+
+// TestToRGB565FastPaths checks that the *image.RGBA/*image.NRGBA/*image.Gray
+// fast paths agree with the generic color.Color-based conversion, including
+// on a sub-image whose Bounds().Min isn't the origin.
+func TestToRGB565FastPaths(t *testing.T) {
+	full := image.Rect(0, 0, 4, 3)
+	sub := image.Rect(1, 1, 4, 3)
+
+	mk := func() (*image.RGBA, *image.NRGBA, *image.Gray) {
+		rgba := image.NewRGBA(full)
+		nrgba := image.NewNRGBA(full)
+		gray := image.NewGray(full)
+		i := 0
+		for y := full.Min.Y; y < full.Max.Y; y++ {
+			for x := full.Min.X; x < full.Max.X; x++ {
+				c := color.RGBA{R: uint8(i * 7), G: uint8(i * 13), B: uint8(i * 29), A: 0xFF}
+				rgba.Set(x, y, c)
+				nrgba.Set(x, y, c)
+				gray.Set(x, y, c)
+				i++
+			}
+		}
+		return rgba, nrgba, gray
+	}
+
+	rgba, nrgba, gray := mk()
+
+	cases := []struct {
+		name string
+		img  image.Image
+	}{
+		{"RGBA full", rgba},
+		{"RGBA sub", rgba.SubImage(sub)},
+		{"NRGBA full", nrgba},
+		{"NRGBA sub", nrgba.SubImage(sub)},
+		{"Gray full", gray},
+		{"Gray sub", gray.SubImage(sub)},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.img.Bounds()
+			fast := make([]byte, b.Dx()*b.Dy()*2)
+			switch src := tt.img.(type) {
+			case *image.RGBA:
+				rgbaToRGB565(fast, src, b)
+			case *image.NRGBA:
+				nrgbaToRGB565(fast, src, b)
+			case *image.Gray:
+				grayToRGB565(fast, src, b)
+			default:
+				t.Fatalf("unexpected type %T", tt.img)
+			}
+
+			generic := make([]byte, b.Dx()*b.Dy()*2)
+			genericToRGB565(generic, tt.img, b)
+
+			if !bytes.Equal(fast, generic) {
+				t.Errorf("fast path disagrees with generic path:\nfast:    % x\ngeneric: % x", fast, generic)
+			}
+		})
+	}
+}
+
+func TestToRGB565Dispatch(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{R: 0xFF, A: 0xFF})
+	got := toRGB565(img)
+	if len(got) != 2*2*2 {
+		t.Fatalf("len(toRGB565(img)) = %d, want %d", len(got), 2*2*2)
+	}
+	if got[0] != 0xF8 || got[1] != 0x00 {
+		t.Errorf("toRGB565 pixel 0 = %02x%02x, want f800", got[0], got[1])
+	}
+}
@@ -0,0 +1,202 @@
+package st7735
+
+import (
+	"fmt"
+	"time"
+)
+
+// Model describes the panel geometry, color order and bring-up sequence of
+// a ST7735/ST7789 variant, in its native, unrotated (Rotation0)
+// orientation. Dev remaps Width/Height and the column/row offsets at
+// runtime to account for the active Rotation.
+//
+// Use GetModel/MustModel to get one of the built-in variants registered
+// with RegisterModel, or implement Model directly for a panel this package
+// doesn't ship.
+type Model interface {
+	OffsetX() byte
+	OffsetXEnd() byte
+	OffsetY() byte
+	OffsetYEnd() byte
+	// Width is the panel's native width, in pixels, at Rotation0.
+	Width() int
+	// Height is the panel's native height, in pixels, at Rotation0.
+	Height() int
+	// BGR reports whether the panel wants BGR, rather than RGB, pixel
+	// color order.
+	BGR() bool
+	// PixelFormat is the COLMOD data byte to program, e.g. 0x05 for
+	// 16 bits/pixel on the ST7735, 0x55 on the ST7789.
+	PixelFormat() byte
+	// InitSequence is the model-specific bring-up sequence, sent after
+	// SWRESET/SLPOUT and before MADCTL/COLMOD/CASET/RASET/DISPON, which
+	// Dev programs generically from the other Model methods.
+	InitSequence() []Command
+}
+
+// modelSpec is a data-driven Model: a panel's native geometry, default
+// color order, pixel format and init sequence, as registered by
+// RegisterModel.
+type modelSpec struct {
+	width, height    int
+	offsetX, offsetY byte
+	bgr              bool
+	pixelFormat      byte
+	initSequence     []Command
+}
+
+func (m modelSpec) OffsetX() byte    { return m.offsetX }
+func (m modelSpec) OffsetXEnd() byte { return byte(m.width-1) + m.offsetX }
+func (m modelSpec) OffsetY() byte    { return m.offsetY }
+func (m modelSpec) OffsetYEnd() byte { return byte(m.height-1) + m.offsetY }
+func (m modelSpec) Width() int       { return m.width }
+func (m modelSpec) Height() int      { return m.height }
+func (m modelSpec) BGR() bool        { return m.bgr }
+func (m modelSpec) PixelFormat() byte {
+	return m.pixelFormat
+}
+func (m modelSpec) InitSequence() []Command { return m.initSequence }
+
+// models holds the registry populated by RegisterModel.
+var models = map[string]func() Model{}
+
+// RegisterModel makes a panel variant available to GetModel/MustModel under
+// name. It's meant to be called from package init functions, including by
+// third-party code registering support for a panel this package doesn't
+// ship.
+func RegisterModel(name string, factory func() Model) {
+	models[name] = factory
+}
+
+// GetModel looks up a panel variant registered with RegisterModel.
+func GetModel(name string) (Model, error) {
+	factory, ok := models[name]
+	if !ok {
+		return nil, fmt.Errorf("st7735: unknown model %q", name)
+	}
+	return factory(), nil
+}
+
+// MustModel is like GetModel but panics if name isn't registered. It's
+// meant for use at New call sites: st7735.New(port, dc, rst, cs,
+// st7735.MustModel("st7789-240x240")).
+func MustModel(name string) Model {
+	m, err := GetModel(name)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func init() {
+	RegisterModel("st7735r-green", newST7735RGreenTab)
+	RegisterModel("st7735r-red", newST7735RRedTab)
+	RegisterModel("st7735s", newST7735S)
+	RegisterModel("st7735b", newST7735B)
+	RegisterModel("st7789-240x240", newST7789_240x240)
+}
+
+// st7735rFrameAndPowerControl is the FRMCTR1-3/INVCTR/PWCTR1-5/VMCTR1
+// sequence shared by the ST7735R tab variants.
+func st7735rFrameAndPowerControl() []Command {
+	return []Command{
+		{Command: frameControl1, Data: []byte{0x01, 0x2C, 0x2D}},
+		{Command: frameControl2, Data: []byte{0x01, 0x2C, 0x2D}},
+		{Command: frameControl3, Data: []byte{0x01, 0x2C, 0x2D, 0x01, 0x2C, 0x2D}},
+		{Command: invControl, Data: []byte{0x07}},
+		{Command: powerControl1, Data: []byte{0xA2, 0x02, 0x84}},
+		{Command: powerControl2, Data: []byte{0xC5}},
+		{Command: powerControl3, Data: []byte{0x0A, 0x00}},
+		{Command: powerControl4, Data: []byte{0x8A, 0x2A}},
+		{Command: powerControl5, Data: []byte{0x8A, 0xEE}},
+		{Command: vmControl1, Data: []byte{0x0E}},
+		{Command: inverseOff},
+	}
+}
+
+// st7735rGamma is the positive/negative gamma curve shared by the ST7735R
+// tab variants.
+func st7735rGamma() []Command {
+	return []Command{
+		{Command: gammaControlPositive, Data: []byte{0x02, 0x1C, 0x07, 0x12, 0x37, 0x32, 0x29, 0x2D, 0x29, 0x25, 0x2B, 0x39, 0x00, 0x01, 0x03, 0x10}},
+		{Command: gammaControlNegative, Data: []byte{0x03, 0x1d, 0x07, 0x06, 0x2E, 0x2C, 0x29, 0x2D, 0x2E, 0x2E, 0x37, 0x3F, 0x00, 0x00, 0x02, 0x10}},
+	}
+}
+
+// newST7735RGreenTab is Adafruit's 1.44" 128x128 "green tab" breakout: a 2
+// pixel column offset and 3 pixel row offset into the controller's 132x162
+// RAM.
+func newST7735RGreenTab() Model {
+	seq := append(st7735rFrameAndPowerControl(), st7735rGamma()...)
+	return modelSpec{
+		width: 128, height: 128,
+		offsetX: 2, offsetY: 3,
+		bgr:          true,
+		pixelFormat:  0x05,
+		initSequence: seq,
+	}
+}
+
+// newST7735RRedTab is Adafruit's 128x160 "red tab" breakout, with no RAM
+// offset.
+func newST7735RRedTab() Model {
+	seq := append(st7735rFrameAndPowerControl(), st7735rGamma()...)
+	return modelSpec{
+		width: 128, height: 160,
+		offsetX: 0, offsetY: 0,
+		bgr:          true,
+		pixelFormat:  0x05,
+		initSequence: seq,
+	}
+}
+
+// newST7735S is the 128x128 ST7735S clone found on many 0.96" breakouts; it
+// shares the ST7735R bring-up sequence and BGR color order, but has no RAM
+// offset.
+func newST7735S() Model {
+	seq := append(st7735rFrameAndPowerControl(), st7735rGamma()...)
+	return modelSpec{
+		width: 128, height: 128,
+		offsetX: 0, offsetY: 0,
+		bgr:          true,
+		pixelFormat:  0x05,
+		initSequence: seq,
+	}
+}
+
+// newST7735B is the ST7735B, which has simpler power-on defaults and skips
+// the FRMCTR/PWCTR/VMCTR programming the R variants need.
+func newST7735B() Model {
+	return modelSpec{
+		width: 128, height: 160,
+		offsetX: 0, offsetY: 0,
+		pixelFormat: 0x05,
+		initSequence: []Command{
+			{Command: inverseOff},
+			{Command: gammaCurve, Data: []byte{0x04}},
+		},
+	}
+}
+
+// newST7789_240x240 is a common 240x240 square ST7789 clone, e.g. as found
+// on many 1.3"/1.54" breakouts.
+func newST7789_240x240() Model {
+	return modelSpec{
+		width: 240, height: 240,
+		offsetX: 0, offsetY: 0,
+		pixelFormat: 0x55,
+		initSequence: []Command{
+			{Command: porchControl, Data: []byte{0x0C, 0x0C, 0x00, 0x33, 0x33}},
+			{Command: gateControl, Data: []byte{0x35}},
+			{Command: vcomSet, Data: []byte{0x19}},
+			{Command: lcmControl, Data: []byte{0x2C}},
+			{Command: vdvVrhEnable, Data: []byte{0x01}},
+			{Command: vrhSet, Data: []byte{0x12}},
+			{Command: vdvSet, Data: []byte{0x20}},
+			{Command: powerControl789, Data: []byte{0xA4, 0xA1}},
+			{Command: frameRateCtrl2, Data: []byte{0x0F}},
+			{Command: gammaControlPositive, Data: []byte{0xD0, 0x04, 0x0D, 0x11, 0x13, 0x2B, 0x3F, 0x54, 0x4C, 0x18, 0x0D, 0x0B, 0x1F, 0x23}},
+			{Command: gammaControlNegative, Data: []byte{0xD0, 0x04, 0x0C, 0x11, 0x13, 0x2C, 0x3F, 0x44, 0x51, 0x2F, 0x1F, 0x1F, 0x20, 0x23}, Delay: 10 * time.Millisecond},
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package st7735
+
+import "testing"
+
+func TestBuiltinModels(t *testing.T) {
+	for _, name := range []string{"st7735r-green", "st7735r-red", "st7735s", "st7735b", "st7789-240x240"} {
+		t.Run(name, func(t *testing.T) {
+			m, err := GetModel(name)
+			if err != nil {
+				t.Fatalf("GetModel(%q) returned error: %v", name, err)
+			}
+			if m.Width() <= 0 || m.Height() <= 0 {
+				t.Errorf("GetModel(%q): Width/Height = %d/%d, want positive", name, m.Width(), m.Height())
+			}
+			if int(m.OffsetXEnd())-int(m.OffsetX())+1 != m.Width() {
+				t.Errorf("GetModel(%q): OffsetX/OffsetXEnd don't span Width()", name)
+			}
+			if int(m.OffsetYEnd())-int(m.OffsetY())+1 != m.Height() {
+				t.Errorf("GetModel(%q): OffsetY/OffsetYEnd don't span Height()", name)
+			}
+		})
+	}
+}
+
+func TestGetModelUnknown(t *testing.T) {
+	if _, err := GetModel("does-not-exist"); err == nil {
+		t.Error("GetModel with an unregistered name should return an error")
+	}
+}
+
+func TestMustModelPanicsOnUnknown(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustModel with an unregistered name should panic")
+		}
+	}()
+	MustModel("does-not-exist")
+}
+
+func TestMustModel(t *testing.T) {
+	m := MustModel("st7789-240x240")
+	if m.Width() != 240 || m.Height() != 240 {
+		t.Errorf("MustModel(\"st7789-240x240\") = %dx%d, want 240x240", m.Width(), m.Height())
+	}
+}
+
+func TestRegisterModel(t *testing.T) {
+	RegisterModel("test-custom", func() Model {
+		return fakeModel{w: 42, h: 24}
+	})
+	m, err := GetModel("test-custom")
+	if err != nil {
+		t.Fatalf("GetModel(\"test-custom\") returned error: %v", err)
+	}
+	if m.Width() != 42 || m.Height() != 24 {
+		t.Errorf("GetModel(\"test-custom\") = %dx%d, want 42x24", m.Width(), m.Height())
+	}
+}
@@ -0,0 +1,97 @@
+package st7735
+
+import (
+	"errors"
+	"time"
+
+	"periph.io/x/periph/conn"
+	"periph.io/x/periph/conn/gpio"
+	"periph.io/x/periph/conn/physic"
+)
+
+var _ conn.Resource = &Dev{}
+
+// backlightPWMFreq is the PWM frequency used to drive a Backlight pin for
+// brightness control. It's well above the threshold for visible flicker.
+const backlightPWMFreq = 1 * physic.KiloHertz
+
+// Sleep puts the panel into its low-power sleep mode. No memory or command
+// other than Wake should be sent until Wake is called.
+func (d *Dev) Sleep() error {
+	return d.send(Command{Command: sleepIn, Delay: 5 * time.Millisecond})
+}
+
+// Wake brings the panel back out of Sleep.
+func (d *Dev) Wake() error {
+	return d.send(Command{Command: sleepOut, Delay: 120 * time.Millisecond})
+}
+
+// SetIdle toggles the panel's idle mode, a reduced-power mode that limits
+// the display to 8 colors.
+func (d *Dev) SetIdle(enable bool) error {
+	cmd := byte(idleOff)
+	if enable {
+		cmd = idleOn
+	}
+	return d.send(Command{Command: cmd})
+}
+
+// SetInvert toggles display color inversion.
+func (d *Dev) SetInvert(enable bool) error {
+	cmd := byte(inverseOff)
+	if enable {
+		cmd = inverseOn
+	}
+	return d.send(Command{Command: cmd})
+}
+
+// SetPartial restricts active display updates to the rows in
+// [startRow, endRow] and enables partial display mode, which draws less of
+// the panel and so uses less power. Pass the panel's full row range to
+// return to normal full-frame display.
+func (d *Dev) SetPartial(startRow, endRow uint16) error {
+	_, h := d.dimensions()
+	if endRow >= uint16(h) || startRow > endRow {
+		return errors.New("st7735: invalid partial row range")
+	}
+	if startRow == 0 && endRow == uint16(h-1) {
+		return d.send(Command{Command: partialOff})
+	}
+
+	if err := d.send(Command{
+		Command: partialStart,
+		Data: []byte{
+			byte(startRow >> 8), byte(startRow),
+			byte(endRow >> 8), byte(endRow),
+		},
+	}); err != nil {
+		return err
+	}
+	return d.send(Command{Command: partialOn})
+}
+
+// SetBrightness drives the duty cycle of the Backlight pin given to New,
+// for panels wired to a PWM-capable pin. fraction is clamped to [0, 1].
+func (d *Dev) SetBrightness(fraction float32) error {
+	if d.bl == nil {
+		return errors.New("st7735: no backlight pin configured, see the Backlight option")
+	}
+	if fraction < 0 {
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+	return d.bl.PWM(gpio.Duty(fraction*float32(gpio.DutyMax)), backlightPWMFreq)
+}
+
+// Halt implements conn.Resource. It puts the panel to sleep and turns off
+// the backlight, if one was configured.
+func (d *Dev) Halt() error {
+	if err := d.Sleep(); err != nil {
+		return err
+	}
+	if d.bl != nil {
+		return d.bl.Out(gpio.Low)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package st7735
+
+import "testing"
+
+func TestSetPartialInvalidRange(t *testing.T) {
+	d := &Dev{Model: fakeModel{w: 128, h: 160}}
+	tests := []struct {
+		name             string
+		startRow, endRow uint16
+	}{
+		{"endRow beyond panel height", 0, 160},
+		{"startRow after endRow", 10, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := d.SetPartial(tt.startRow, tt.endRow); err == nil {
+				t.Errorf("SetPartial(%d, %d) should have returned an error", tt.startRow, tt.endRow)
+			}
+		})
+	}
+}
+
+func TestSetBrightnessNoBacklight(t *testing.T) {
+	d := &Dev{Model: fakeModel{}}
+	if err := d.SetBrightness(0.5); err == nil {
+		t.Error("SetBrightness without a Backlight option should return an error")
+	}
+}
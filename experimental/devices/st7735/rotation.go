@@ -0,0 +1,100 @@
+package st7735
+
+import "errors"
+
+// Rotation is a panel orientation, applied through the MADCTL command.
+type Rotation int
+
+const (
+	// Rotation0 is the panel's native orientation, as wired by Model.
+	Rotation0 Rotation = iota
+	// Rotation90 rotates the panel 90 degrees clockwise.
+	Rotation90
+	// Rotation180 rotates the panel 180 degrees.
+	Rotation180
+	// Rotation270 rotates the panel 270 degrees clockwise.
+	Rotation270
+)
+
+// MADCTL (Memory Data Access Control) bits, per the ST7735 datasheet.
+const (
+	madctlMY  = 1 << 7 // row address order
+	madctlMX  = 1 << 6 // column address order
+	madctlMV  = 1 << 5 // row/column exchange
+	madctlML  = 1 << 4 // vertical refresh order
+	madctlRGB = 1 << 3 // RGB/BGR order, 0 means RGB
+	madctlMH  = 1 << 2 // horizontal refresh order
+)
+
+// madctl computes the MADCTL byte for the current rotation and mirror
+// settings. The color order comes from Model.BGR; MY/MX/MV encode the
+// rotation, then SetMirror flips MX/MY independently on top of it.
+func (d *Dev) madctl() byte {
+	var m byte
+	if d.Model.BGR() {
+		m = madctlRGB
+	}
+	switch d.rotation {
+	case Rotation0:
+		m |= madctlMX | madctlMY
+	case Rotation90:
+		m |= madctlMY | madctlMV
+	case Rotation180:
+	case Rotation270:
+		m |= madctlMX | madctlMV
+	}
+	if d.mirrorX {
+		m ^= madctlMX
+	}
+	if d.mirrorY {
+		m ^= madctlMY
+	}
+	return m
+}
+
+// SetRotation reprograms MADCTL so that subsequent SetImage/SetImageRect
+// calls use the given orientation. It swaps the effective width/height and
+// column/row offsets accordingly.
+func (d *Dev) SetRotation(r Rotation) error {
+	if r < Rotation0 || r > Rotation270 {
+		return errors.New("st7735: invalid rotation")
+	}
+	d.rotation = r
+	d.fbPrimed = false
+	return d.send(Command{Command: memoryDAC, Data: []byte{d.madctl()}})
+}
+
+// SetMirror flips the image horizontally (x) and/or vertically (y), on top
+// of whatever rotation is currently active.
+func (d *Dev) SetMirror(x, y bool) error {
+	d.mirrorX = x
+	d.mirrorY = y
+	d.fbPrimed = false
+	return d.send(Command{Command: memoryDAC, Data: []byte{d.madctl()}})
+}
+
+// dimensions returns the logical width and height of the panel for the
+// currently active rotation, swapping Model's native dimensions when the
+// panel is turned on its side.
+func (d *Dev) dimensions() (w, h int) {
+	w, h = d.Model.Width(), d.Model.Height()
+	if d.rotation == Rotation90 || d.rotation == Rotation270 {
+		w, h = h, w
+	}
+	return w, h
+}
+
+// windowOffsets returns the column/row offsets to program into the
+// controller for the currently active rotation, remapping Model's
+// Rotation0 offsets. They're widened to uint16 since CASET/RASET take
+// 16-bit coordinates and larger panels (e.g. a 240-row ST7789) overflow a
+// byte once an offset is added to a row/column index.
+func (d *Dev) windowOffsets() (x0, x1, y0, y1 uint16) {
+	ox, oxEnd, oy, oyEnd := d.Model.OffsetX(), d.Model.OffsetXEnd(), d.Model.OffsetY(), d.Model.OffsetYEnd()
+	switch d.rotation {
+	case Rotation90, Rotation270:
+		return uint16(oy), uint16(oyEnd), uint16(ox), uint16(oxEnd)
+	default:
+		return uint16(ox), uint16(oxEnd), uint16(oy), uint16(oyEnd)
+	}
+}
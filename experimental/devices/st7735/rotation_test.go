@@ -0,0 +1,104 @@
+package st7735
+
+import "testing"
+
+// fakeModel is a minimal Model used to drive madctl/windowOffsets/
+// dimensions tests without touching any hardware.
+type fakeModel struct {
+	w, h                         int
+	offX, offXEnd, offY, offYEnd byte
+	bgr                          bool
+}
+
+func (m fakeModel) OffsetX() byte           { return m.offX }
+func (m fakeModel) OffsetXEnd() byte        { return m.offXEnd }
+func (m fakeModel) OffsetY() byte           { return m.offY }
+func (m fakeModel) OffsetYEnd() byte        { return m.offYEnd }
+func (m fakeModel) Width() int              { return m.w }
+func (m fakeModel) Height() int             { return m.h }
+func (m fakeModel) BGR() bool               { return m.bgr }
+func (m fakeModel) PixelFormat() byte       { return 0x05 }
+func (m fakeModel) InitSequence() []Command { return nil }
+
+func TestMadctl(t *testing.T) {
+	tests := []struct {
+		name             string
+		rotation         Rotation
+		bgr              bool
+		mirrorX, mirrorY bool
+		want             byte
+	}{
+		{"rotation0 rgb", Rotation0, false, false, false, madctlMX | madctlMY},
+		{"rotation0 bgr", Rotation0, true, false, false, madctlMX | madctlMY | madctlRGB},
+		{"rotation90", Rotation90, false, false, false, madctlMY | madctlMV},
+		{"rotation180", Rotation180, false, false, false, 0},
+		{"rotation270", Rotation270, false, false, false, madctlMX | madctlMV},
+		{"rotation0 mirrorX", Rotation0, false, true, false, madctlMY},
+		{"rotation0 mirrorY", Rotation0, false, false, true, madctlMX},
+		{"rotation0 mirrorXY", Rotation0, false, true, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dev{
+				Model:    fakeModel{bgr: tt.bgr},
+				rotation: tt.rotation,
+				mirrorX:  tt.mirrorX,
+				mirrorY:  tt.mirrorY,
+			}
+			if got := d.madctl(); got != tt.want {
+				t.Errorf("madctl() = %#08b, want %#08b", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetRotationInvalid(t *testing.T) {
+	d := &Dev{Model: fakeModel{}}
+	if err := d.SetRotation(Rotation270 + 1); err == nil {
+		t.Error("SetRotation with an out-of-range value should return an error")
+	}
+	if err := d.SetRotation(Rotation0 - 1); err == nil {
+		t.Error("SetRotation with an out-of-range value should return an error")
+	}
+}
+
+func TestDimensions(t *testing.T) {
+	m := fakeModel{w: 128, h: 160}
+	tests := []struct {
+		rotation Rotation
+		wantW    int
+		wantH    int
+	}{
+		{Rotation0, 128, 160},
+		{Rotation90, 160, 128},
+		{Rotation180, 128, 160},
+		{Rotation270, 160, 128},
+	}
+	for _, tt := range tests {
+		d := &Dev{Model: m, rotation: tt.rotation}
+		if w, h := d.dimensions(); w != tt.wantW || h != tt.wantH {
+			t.Errorf("rotation %v: dimensions() = (%d, %d), want (%d, %d)", tt.rotation, w, h, tt.wantW, tt.wantH)
+		}
+	}
+}
+
+func TestWindowOffsets(t *testing.T) {
+	m := fakeModel{offX: 2, offXEnd: 129, offY: 3, offYEnd: 162}
+	tests := []struct {
+		rotation                       Rotation
+		wantX0, wantX1, wantY0, wantY1 uint16
+	}{
+		{Rotation0, 2, 129, 3, 162},
+		{Rotation180, 2, 129, 3, 162},
+		{Rotation90, 3, 162, 2, 129},
+		{Rotation270, 3, 162, 2, 129},
+	}
+	for _, tt := range tests {
+		d := &Dev{Model: m, rotation: tt.rotation}
+		x0, x1, y0, y1 := d.windowOffsets()
+		if x0 != tt.wantX0 || x1 != tt.wantX1 || y0 != tt.wantY0 || y1 != tt.wantY1 {
+			t.Errorf("rotation %v: windowOffsets() = (%d, %d, %d, %d), want (%d, %d, %d, %d)",
+				tt.rotation, x0, x1, y0, y1, tt.wantX0, tt.wantX1, tt.wantY0, tt.wantY1)
+		}
+	}
+}
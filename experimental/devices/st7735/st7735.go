@@ -1,9 +1,8 @@
 package st7735
 
 import (
-	"encoding/binary"
 	"errors"
-	"image"
+	"fmt"
 	"time"
 
 	"periph.io/x/periph/conn"
@@ -13,9 +12,6 @@ import (
 )
 
 const (
-	width  = 162
-	height = 132
-
 	nOP           = 0x00
 	softwareRst   = 0x01
 	readID        = 0x04
@@ -59,52 +55,20 @@ const (
 	//gamma control
 	gammaControlPositive = 0xE0
 	gammaControlNegative = 0xE1
-)
-
-type Model interface {
-	OffsetX() byte
-	OffsetXEnd() byte
-	OffsetY() byte
-	OffsetYEnd() byte
-}
-
-type ModelStandard struct {
-}
-
-func (mm ModelStandard) OffsetX() byte {
-	return 0
-}
-
-func (mm ModelStandard) OffsetXEnd() byte {
-	return width - 1
-}
-
-func (mm ModelStandard) OffsetY() byte {
-	return 0
-}
-
-func (mm ModelStandard) OffsetYEnd() byte {
-	return height - 1
-}
-
-type ModelMini struct {
-}
-
-func (mm ModelMini) OffsetY() byte {
-	return (width - 160) / 2
-}
-
-func (mm ModelMini) OffsetYEnd() byte {
-	return (160 + mm.OffsetY()) - 1
-}
-
-func (mm ModelMini) OffsetX() byte {
-	return (height - 80) / 2
-}
 
-func (mm ModelMini) OffsetXEnd() byte {
-	return (80 + mm.OffsetX()) - 1
-}
+	// ST7789-specific extended commands; the core commands above (SWRESET,
+	// SLPOUT, MADCTL, COLMOD, CASET, RASET, RAMWR, DISPON, ...) are shared
+	// across the whole st773x family.
+	porchControl    = 0xB2
+	gateControl     = 0xB7
+	vcomSet         = 0xBB
+	lcmControl      = 0xC0
+	vdvVrhEnable    = 0xC2
+	vrhSet          = 0xC3
+	vdvSet          = 0xC4
+	frameRateCtrl2  = 0xC6
+	powerControl789 = 0xD0
+)
 
 type Command struct {
 	Command byte
@@ -126,10 +90,43 @@ type Dev struct {
 	maxTxSize int
 
 	Model Model
+
+	// rotation is the currently active orientation, applied through MADCTL.
+	rotation Rotation
+	// mirrorX and mirrorY additionally flip the axes on top of rotation.
+	mirrorX, mirrorY bool
+
+	// fb is the shadow framebuffer holding the last frame sent to the
+	// panel in RGB565, so that SetImage only pushes rows that changed.
+	fb []byte
+	// fbPrimed is false until the first full frame has been sent: the
+	// panel's RAM is in an undefined state at power-on, so fb can't be
+	// trusted as a diff baseline until then.
+	fbPrimed bool
+
+	// bl is the optional backlight pin set via the Backlight option.
+	bl gpio.PinIO
+
+	// te is the optional tearing-effect input pin set via the
+	// WithTearingEffect option.
+	te     gpio.PinIn
+	teMode TEMode
+}
+
+// Option alters the behavior of New. See Backlight.
+type Option func(*Dev)
+
+// Backlight drives pin as the panel's backlight: on at full brightness once
+// New returns, and off once Halt is called. If pin supports PWM, its
+// brightness can also be adjusted with SetBrightness.
+func Backlight(pin gpio.PinIO) Option {
+	return func(d *Dev) {
+		d.bl = pin
+	}
 }
 
 // New opens a handle to a ST7735 LCD.
-func New(p spi.Port, dc gpio.PinOut, rst gpio.PinOut, cs gpio.PinIn, m Model) (*Dev, error) {
+func New(p spi.Port, dc gpio.PinOut, rst gpio.PinOut, cs gpio.PinIn, m Model, opts ...Option) (*Dev, error) {
 	c, err := p.Connect(4000*physic.KiloHertz, spi.Mode0, 8)
 
 	if err != nil {
@@ -156,6 +153,22 @@ func New(p spi.Port, dc gpio.PinOut, rst gpio.PinOut, cs gpio.PinIn, m Model) (*
 		Model:     m,
 	}
 
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	if d.bl != nil {
+		if err := d.bl.Out(gpio.High); err != nil {
+			return nil, err
+		}
+	}
+
+	if d.te != nil {
+		if err := d.te.In(gpio.PullDown, gpio.RisingEdge); err != nil {
+			return nil, err
+		}
+	}
+
 	if err = d.reset(); err != nil {
 		return nil, err
 	}
@@ -169,82 +182,32 @@ func New(p spi.Port, dc gpio.PinOut, rst gpio.PinOut, cs gpio.PinIn, m Model) (*
 		Command: sleepOut,
 		Delay:   time.Duration(50 * time.Millisecond),
 	})
-	cmd = append(cmd, Command{
-		Command: frameControl1,
-		Data:    []byte{0x01, 0x2C, 0x2D},
-	})
-	cmd = append(cmd, Command{
-		Command: frameControl2,
-		Data:    []byte{0x01, 0x2C, 0x2D},
-	})
-	cmd = append(cmd, Command{
-		Command: frameControl3,
-		Data:    []byte{0x01, 0x2C, 0x2D, 0x01, 0x2C, 0x2D},
-	})
-	cmd = append(cmd, Command{
-		Command: invControl,
-		Data:    []byte{0x07},
-	})
-	cmd = append(cmd, Command{
-		Command: powerControl1,
-		Data:    []byte{0xA2, 0x02, 0x84},
-	})
-	cmd = append(cmd, Command{
-		Command: powerControl2,
-		Data:    []byte{0xC5},
-	})
-	cmd = append(cmd, Command{
-		Command: powerControl3,
-		Data:    []byte{0x0A, 0x00},
-	})
-	cmd = append(cmd, Command{
-		Command: powerControl4,
-		Data:    []byte{0x8A, 0x2A},
-	})
-	cmd = append(cmd, Command{
-		Command: powerControl5,
-		Data:    []byte{0x8A, 0xEE},
-	})
-	cmd = append(cmd, Command{
-		Command: vmControl1,
-		Data:    []byte{0x0E},
-	})
-	cmd = append(cmd, Command{
-		Command: inverseOff,
-		Data:    nil,
-	})
+	cmd = append(cmd, d.Model.InitSequence()...)
+	if d.te != nil {
+		cmd = append(cmd, Command{Command: tearingOn, Data: []byte{byte(d.teMode)}})
+	}
 	cmd = append(cmd, Command{
 		Command: memoryDAC,
-		Data:    []byte{0xC8},
+		Data:    []byte{d.madctl()},
 	})
 	cmd = append(cmd, Command{
 		Command: pixelFormat,
-		Data:    []byte{0x05},
+		Data:    []byte{d.Model.PixelFormat()},
 	})
 	cmd = append(cmd, Command{
 		Command: columnAddress,
-		Data:    []byte{columnAddress, 0x00, byte(d.Model.OffsetX()), 0x00, byte(d.Model.OffsetXEnd())},
+		Data:    []byte{0x00, byte(d.Model.OffsetX()), 0x00, byte(d.Model.OffsetXEnd())},
 	})
 	cmd = append(cmd, Command{
 		Command: rowAddress,
-		Data:    []byte{rowAddress, 0x00, byte(d.Model.OffsetY()), 0x00, byte(d.Model.OffsetYEnd())},
-	})
-	cmd = append(cmd, Command{
-		Command: gammaControlPositive,
-		Data:    []byte{0x02, 0x1C, 0x07, 0x12, 0x37, 0x32, 0x29, 0x2D, 0x29, 0x25, 0x2B, 0x39, 0x00, 0x01, 0x03, 0x10},
-	})
-	cmd = append(cmd, Command{
-		Command: gammaControlNegative,
-		Data:    []byte{0x03, 0x1d, 0x07, 0x06, 0x2E, 0x2C, 0x29, 0x2D, 0x2E, 0x2E, 0x37, 0x3F, 0x00, 0x00, 0x02, 0x10},
+		Data:    []byte{0x00, byte(d.Model.OffsetY()), 0x00, byte(d.Model.OffsetYEnd())},
 	})
 	cmd = append(cmd, Command{
 		Command: partialOff,
-		Data:    []byte{0x03, 0x1d, 0x07, 0x06, 0x2E, 0x2C, 0x29, 0x2D, 0x2E, 0x2E, 0x37, 0x3F, 0x00, 0x00, 0x02, 0x10},
 		Delay:   time.Duration(10 * time.Millisecond),
 	})
 	cmd = append(cmd, Command{
 		Command: displayOn,
-		Data:    []byte{0x03, 0x1d, 0x07, 0x06, 0x2E, 0x2C, 0x29, 0x2D, 0x2E, 0x2E, 0x37, 0x3F, 0x00, 0x00, 0x02, 0x10},
 		Delay:   time.Duration(100 * time.Millisecond),
 	})
 
@@ -257,14 +220,14 @@ func New(p spi.Port, dc gpio.PinOut, rst gpio.PinOut, cs gpio.PinIn, m Model) (*
 	return d, nil
 }
 
-func (d *Dev) setWindowAddress(x0, x1, y0, y1 byte) error {
+func (d *Dev) setWindowAddress(x0, x1, y0, y1 uint16) error {
 	column := Command{
 		Command: columnAddress,
 		Data: []byte{
-			x0 >> 8,
-			x0,
-			x1 >> 8,
-			x1,
+			byte(x0 >> 8),
+			byte(x0),
+			byte(x1 >> 8),
+			byte(x1),
 		},
 	}
 
@@ -275,10 +238,10 @@ func (d *Dev) setWindowAddress(x0, x1, y0, y1 byte) error {
 	row := Command{
 		Command: rowAddress,
 		Data: []byte{
-			y0 >> 8,
-			y0,
-			y1 >> 8,
-			y1,
+			byte(y0 >> 8),
+			byte(y0),
+			byte(y1 >> 8),
+			byte(y1),
 		},
 	}
 
@@ -286,42 +249,15 @@ func (d *Dev) setWindowAddress(x0, x1, y0, y1 byte) error {
 		return err
 	}
 
-	if err := d.sendCommand([]byte{memoryWrite}); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (d *Dev) SetImage(img image.Image) error {
-	if err := d.setWindowAddress(d.Model.OffsetX(), d.Model.OffsetXEnd(), d.Model.OffsetY(), d.Model.OffsetYEnd()); err != nil {
-		return err
-	}
+	d.waitForTE()
 
-	if err := d.sendData(toRGB565(img)); err != nil {
+	if err := d.sendCommand([]byte{memoryWrite}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func toRGB565(img image.Image) []byte {
-	b := img.Bounds()
-	rgb565 := make([]byte, b.Dx()*b.Dy()*2)
-
-	i := 0
-
-	for x := 0; x < b.Max.X; x++ {
-		for y := 0; y < b.Max.Y; y++ {
-			r, g, b, _ := img.At(x, y).RGBA()
-			binary.BigEndian.PutUint16(rgb565[i:i+2], uint16((r<<8)&0b1111100000000000|(g<<3)&0b0000011111100000|(b>>3)&0b0000000000011111))
-			i += 2
-		}
-	}
-
-	return rgb565
-}
-
 func (d *Dev) send(command Command) error {
 	if err := d.sendCommand([]byte{command.Command}); err != nil {
 		return err
@@ -366,6 +302,11 @@ func (d *Dev) sendData(data []byte) error {
 	return nil
 }
 
+// String implements conn.Resource.
+func (d *Dev) String() string {
+	return fmt.Sprintf("st7735.Dev{%s}", d.c)
+}
+
 func (d *Dev) reset() error {
 	if err := d.rst.Out(gpio.High); err != nil {
 		return err
@@ -0,0 +1,44 @@
+package st7735
+
+import (
+	"time"
+
+	"periph.io/x/periph/conn/gpio"
+)
+
+// TEMode selects which blanking interval the panel's tearing-effect output
+// pulses for, per the TEON (0x35) command.
+type TEMode byte
+
+const (
+	// TEModeVBlank pulses TE only during the vertical blanking interval.
+	TEModeVBlank TEMode = 0x00
+	// TEModeVHBlank pulses TE during both vertical and horizontal blanking.
+	TEModeVHBlank TEMode = 0x01
+)
+
+// teWaitTimeout bounds how long SetImage/SetImageRect/Draw block waiting
+// for a tearing-effect pulse, so a panel that stops driving TE (or no TE
+// pin wired at all) can't hang a caller forever.
+const teWaitTimeout = 50 * time.Millisecond
+
+// WithTearingEffect has New enable the panel's tearing-effect output in the
+// given mode and configure pin for rising-edge detection. Once set,
+// SetImage/SetImageRect/Draw wait for a TE pulse before transmitting pixel
+// data, so updates land during the panel's blanking interval instead of
+// tearing mid-refresh.
+func WithTearingEffect(pin gpio.PinIn, mode TEMode) Option {
+	return func(d *Dev) {
+		d.te = pin
+		d.teMode = mode
+	}
+}
+
+// waitForTE blocks until the panel's tearing-effect pin pulses, if one was
+// configured with WithTearingEffect.
+func (d *Dev) waitForTE() {
+	if d.te == nil {
+		return
+	}
+	d.te.WaitForEdge(teWaitTimeout)
+}